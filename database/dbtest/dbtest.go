@@ -0,0 +1,82 @@
+// Package dbtest provides a sqlmock-backed GormDBWrapper for tests that
+// need to assert on actual SQL text, argument binding and transaction
+// boundaries, which the method-level stubbing in GormDBWrapper.Mock
+// cannot express.
+package dbtest
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/vigneshsankariyer1234567890/time-capsule-server/database"
+)
+
+// Driver selects which gorm dialector NewMockDBWithDriver wires the
+// sqlmock connection through. The dialector only affects SQL generation
+// (placeholders, quoting, RETURNING clauses, ...); sqlmock never talks
+// to a real database.
+type Driver string
+
+const (
+	MySQL    Driver = "mysql"
+	Postgres Driver = "postgres"
+)
+
+// NewMockDB returns a *database.GormDBWrapper backed by a sqlmock
+// connection using the mysql dialector, and the sqlmock.Sqlmock used to
+// set expectations on it. The underlying sql.DB is closed automatically
+// via t.Cleanup.
+func NewMockDB(t *testing.T) (*database.GormDBWrapper, sqlmock.Sqlmock) {
+	t.Helper()
+	return NewMockDBWithDriver(t, MySQL)
+}
+
+// NewMockDBWithDriver is NewMockDB with an explicit dialector, for tests
+// that need driver-specific SQL (e.g. postgres RETURNING clauses).
+func NewMockDBWithDriver(t *testing.T, driver Driver) (*database.GormDBWrapper, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("dbtest: failed to open sqlmock: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+
+	var dialector gorm.Dialector
+	switch driver {
+	case Postgres:
+		dialector = postgres.New(postgres.Config{
+			Conn:       sqlDB,
+			DriverName: "postgres",
+		})
+	default:
+		dialector = mysql.New(mysql.Config{
+			Conn:                      sqlDB,
+			DriverName:                "mysql",
+			SkipInitializeWithVersion: true,
+		})
+	}
+
+	gormDB, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("dbtest: failed to open gorm over sqlmock: %v", err)
+	}
+
+	return &database.GormDBWrapper{DB: gormDB}, mock
+}
+
+// AssertExpectationsMet fails t if mock has unmet expectations, mirroring
+// the testify mock.Mock.AssertExpectations convention callers already
+// use with GormDBWrapper.Mock.
+func AssertExpectationsMet(t *testing.T, mock sqlmock.Sqlmock) {
+	t.Helper()
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("dbtest: unmet sqlmock expectations: %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package dbtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vigneshsankariyer1234567890/time-capsule-server/database"
+)
+
+type testModel struct {
+	ID   int
+	Name string
+}
+
+func TestNewMockDB_AssertsTransactionBoundaries(t *testing.T) {
+	wrapper, mock := NewMockDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test_models`").
+		WithArgs("Test Name").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := wrapper.Transaction(func(tx database.GormDBInterface) error {
+		return tx.Create(&testModel{Name: "Test Name"}).GetDB().Error
+	})
+
+	assert.NoError(t, err)
+	AssertExpectationsMet(t, mock)
+}
+
+func TestNewMockDBWithDriver_Postgres(t *testing.T) {
+	wrapper, mock := NewMockDBWithDriver(t, Postgres)
+
+	// Unlike mysql, the postgres dialector issues inserts as a query with
+	// a RETURNING clause to recover the generated id, so it's asserted
+	// with ExpectQuery rather than ExpectExec. Outside an explicit
+	// Transaction, gorm wraps the insert in its own BEGIN/COMMIT.
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "test_models"`).
+		WithArgs("Test Name").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := wrapper.Create(&testModel{Name: "Test Name"}).GetDB().Error
+
+	assert.NoError(t, err)
+	AssertExpectationsMet(t, mock)
+}
+
+func TestNewMockDB_RollsBackOnError(t *testing.T) {
+	wrapper, mock := NewMockDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test_models`").
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	// The repo must be built over tx, not the outer wrapper: the INSERT
+	// has to run against the begun transaction sqlmock is tracking, or
+	// it never executes and ExpectExec/ExpectRollback go unmet.
+	err := wrapper.Transaction(func(tx database.GormDBInterface) error {
+		txRepo := database.NewGormDatabase[testModel](tx.(*database.GormDBWrapper))
+		return txRepo.Create(context.Background(), testModel{Name: "Test Name"})
+	})
+
+	assert.Error(t, err)
+	AssertExpectationsMet(t, mock)
+}
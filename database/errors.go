@@ -0,0 +1,103 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Typed sentinels returned by GormRepository methods in place of raw
+// gorm/driver errors, so callers can branch on the failure kind with
+// errors.Is without importing gorm themselves.
+var (
+	ErrNotFound             = errors.New("database: record not found")
+	ErrDuplicateKey         = errors.New("database: duplicate key violates unique constraint")
+	ErrForeignKeyViolation  = errors.New("database: foreign key constraint violation")
+	ErrSerializationFailure = errors.New("database: serialization failure, retry transaction")
+)
+
+// QueryError is returned by GormRepository methods on failure. Sentinel
+// holds one of the typed errors above when the underlying error could be
+// classified, so errors.Is(err, database.ErrNotFound) works; Cause holds
+// the original gorm/driver error for logging and debugging.
+type QueryError struct {
+	Op       string
+	Model    string
+	Sentinel error
+	Cause    error
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("database: %s %s: %v", e.Op, e.Model, e.Cause)
+}
+
+func (e *QueryError) Unwrap() error {
+	if e.Sentinel != nil {
+		return e.Sentinel
+	}
+	return e.Cause
+}
+
+// translateError classifies a raw gorm/driver error into one of the
+// typed sentinels above and wraps it in a QueryError carrying op/model
+// context. It returns nil when err is nil.
+func translateError(err error, op, model string) error {
+	if err == nil {
+		return nil
+	}
+
+	return &QueryError{
+		Op:       op,
+		Model:    model,
+		Sentinel: classifyError(err),
+		Cause:    err,
+	}
+}
+
+func classifyError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrNotFound
+	case isUniqueViolation(err):
+		return ErrDuplicateKey
+	case isForeignKeyViolation(err):
+		return ErrForeignKeyViolation
+	case isSerializationFailure(err):
+		return ErrSerializationFailure
+	default:
+		return nil
+	}
+}
+
+// isUniqueViolation recognizes unique-constraint violations across the
+// sqlite, postgres and mysql drivers by their well-known error codes,
+// since each driver surfaces a different error type.
+func isUniqueViolation(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint failed") || // sqlite
+		strings.Contains(msg, "SQLSTATE 23505") || strings.Contains(msg, "23505") || // postgres unique_violation
+		strings.Contains(msg, "Error 1062") || strings.Contains(msg, "Duplicate entry") // mysql
+}
+
+// isForeignKeyViolation recognizes FK-constraint violations across the
+// sqlite, postgres and mysql drivers.
+func isForeignKeyViolation(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "FOREIGN KEY constraint failed") || // sqlite
+		strings.Contains(msg, "SQLSTATE 23503") || strings.Contains(msg, "23503") || // postgres foreign_key_violation
+		strings.Contains(msg, "Error 1452") // mysql
+}
+
+// isSerializationFailure recognizes the transient "retry the
+// transaction" errors raised under concurrent contention: sqlite's
+// SQLITE_BUSY, postgres' serialization_failure/deadlock_detected, and
+// mysql's lock-wait-timeout/deadlock errors.
+func isSerializationFailure(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "SQLITE_BUSY") || strings.Contains(msg, "database is locked") || // sqlite
+		strings.Contains(msg, "SQLSTATE 40001") || strings.Contains(msg, "40001") || // postgres serialization_failure
+		strings.Contains(msg, "SQLSTATE 40P01") || strings.Contains(msg, "40P01") || // postgres deadlock_detected
+		strings.Contains(msg, "Error 1213") || strings.Contains(msg, "Error 1205") // mysql deadlock / lock wait timeout
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTranslateError_Nil(t *testing.T) {
+	assert.NoError(t, translateError(nil, "Create", "TestModel"))
+}
+
+func TestTranslateError_NotFound(t *testing.T) {
+	err := translateError(gorm.ErrRecordNotFound, "First", "TestModel")
+
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	var queryErr *QueryError
+	assert.True(t, errors.As(err, &queryErr))
+	assert.Equal(t, "First", queryErr.Op)
+	assert.Equal(t, "TestModel", queryErr.Model)
+}
+
+func TestTranslateError_Unclassified(t *testing.T) {
+	fakeErr := errors.New("boom")
+	err := translateError(fakeErr, "Save", "TestModel")
+
+	assert.ErrorIs(t, err, fakeErr)
+	assert.NotErrorIs(t, err, ErrNotFound)
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	assert.True(t, isUniqueViolation(errors.New("UNIQUE constraint failed: test_models.name")))
+	assert.True(t, isUniqueViolation(errors.New("pq: duplicate key value violates unique constraint \"idx\" (SQLSTATE 23505)")))
+	assert.True(t, isUniqueViolation(errors.New("Error 1062: Duplicate entry 'x' for key 'name'")))
+	assert.False(t, isUniqueViolation(errors.New("some other error")))
+}
+
+func TestIsForeignKeyViolation(t *testing.T) {
+	assert.True(t, isForeignKeyViolation(errors.New("FOREIGN KEY constraint failed")))
+	assert.True(t, isForeignKeyViolation(errors.New("pq: insert or update violates foreign key constraint (SQLSTATE 23503)")))
+	assert.True(t, isForeignKeyViolation(errors.New("Error 1452: Cannot add or update a child row")))
+	assert.False(t, isForeignKeyViolation(errors.New("some other error")))
+}
+
+func TestIsSerializationFailure(t *testing.T) {
+	assert.True(t, isSerializationFailure(errors.New("database is locked (SQLITE_BUSY)")))
+	assert.True(t, isSerializationFailure(errors.New("pq: could not serialize access due to concurrent update (SQLSTATE 40001)")))
+	assert.True(t, isSerializationFailure(errors.New("Error 1213: Deadlock found when trying to get lock")))
+	assert.False(t, isSerializationFailure(errors.New("some other error")))
+}
+
+func TestGormRepository_First_NotFound_TranslatesError(t *testing.T) {
+	// See freshWrapper's doc comment: this runs a real query, so it needs
+	// its own isolated session rather than the shared package-level repo.
+	freshRepo := NewGormDatabase[TestModel](freshWrapper())
+
+	_, err := freshRepo.First(context.Background(), "name = ?", "does-not-exist")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ReplicaSelector picks one of the available replica wrappers to serve
+// a single read.
+type ReplicaSelector interface {
+	Select(replicas []*GormDBWrapper) *GormDBWrapper
+}
+
+// LatencyObserver is implemented by ReplicaSelectors that want to be
+// told how long a read against a given replica took, so they can factor
+// that into future selections.
+type LatencyObserver interface {
+	Observe(replica *GormDBWrapper, d time.Duration)
+}
+
+// RoundRobinSelector cycles through replicas in order. It is the
+// default ReplicaSelector used by GormDBPool.
+type RoundRobinSelector struct {
+	next uint64
+}
+
+func (s *RoundRobinSelector) Select(replicas []*GormDBWrapper) *GormDBWrapper {
+	if len(replicas) == 0 {
+		return nil
+	}
+	idx := atomic.AddUint64(&s.next, 1) - 1
+	return replicas[idx%uint64(len(replicas))]
+}
+
+// LatencyAwareSelector tracks an exponentially weighted moving average
+// of each replica's observed query duration and routes reads to
+// whichever replica currently looks fastest. While any replica has no
+// observation yet, the first such replica in slice order is chosen.
+type LatencyAwareSelector struct {
+	// Alpha is the EWMA smoothing factor in (0, 1]; higher weights
+	// recent observations more heavily. Defaults to 0.2 when zero.
+	Alpha float64
+
+	mu   sync.Mutex
+	ewma map[*GormDBWrapper]time.Duration
+}
+
+func NewLatencyAwareSelector() *LatencyAwareSelector {
+	return &LatencyAwareSelector{Alpha: 0.2, ewma: make(map[*GormDBWrapper]time.Duration)}
+}
+
+func (s *LatencyAwareSelector) Select(replicas []*GormDBWrapper) *GormDBWrapper {
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var best *GormDBWrapper
+	var bestLatency time.Duration
+	for _, r := range replicas {
+		lat, observed := s.ewma[r]
+		if !observed {
+			// Prefer replicas we haven't measured yet so every replica
+			// gets an initial observation.
+			return r
+		}
+		if best == nil || lat < bestLatency {
+			best = r
+			bestLatency = lat
+		}
+	}
+	return best
+}
+
+func (s *LatencyAwareSelector) Observe(replica *GormDBWrapper, d time.Duration) {
+	alpha := s.Alpha
+	if alpha <= 0 {
+		alpha = 0.2
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, observed := s.ewma[replica]
+	if !observed {
+		s.ewma[replica] = d
+		return
+	}
+	s.ewma[replica] = time.Duration(alpha*float64(d) + (1-alpha)*float64(prev))
+}
+
+// GormDBPool holds a primary GormDBWrapper used for all writes and a set
+// of replica wrappers used for reads, routed through a pluggable
+// ReplicaSelector. The zero Selector behaves as RoundRobinSelector.
+type GormDBPool struct {
+	Primary  *GormDBWrapper
+	Replicas []*GormDBWrapper
+	Selector ReplicaSelector
+
+	// fallback holds the round-robin state used when Selector is nil.
+	fallback RoundRobinSelector
+}
+
+// NewGormDBPool builds a pool that round-robins reads across replicas.
+// Use the Selector field to opt into LatencyAwareSelector instead.
+func NewGormDBPool(primary *GormDBWrapper, replicas ...*GormDBWrapper) *GormDBPool {
+	return &GormDBPool{
+		Primary:  primary,
+		Replicas: replicas,
+		Selector: &RoundRobinSelector{},
+	}
+}
+
+type forcedPrimaryKey struct{}
+
+// WithForcedPrimary marks ctx so that a read issued through this pool is
+// routed to the primary instead of a replica. Use it for a read that
+// must observe a write it just made, avoiding replication-lag anomalies.
+func WithForcedPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forcedPrimaryKey{}, true)
+}
+
+func isForcedPrimary(ctx context.Context) bool {
+	forced, _ := ctx.Value(forcedPrimaryKey{}).(bool)
+	return forced
+}
+
+// pickReader returns the wrapper a read for ctx should be issued
+// against: the primary when ctx was forced via WithForcedPrimary or no
+// replicas are configured, otherwise a replica chosen by Selector.
+func (p *GormDBPool) pickReader(ctx context.Context) *GormDBWrapper {
+	if isForcedPrimary(ctx) || len(p.Replicas) == 0 {
+		return p.Primary
+	}
+
+	selector := p.Selector
+	if selector == nil {
+		selector = &p.fallback
+	}
+	if replica := selector.Select(p.Replicas); replica != nil {
+		return replica
+	}
+	return p.Primary
+}
+
+// observeLatency reports how long a read against wrapper took to the
+// pool's Selector, when it implements LatencyObserver.
+func (p *GormDBPool) observeLatency(wrapper *GormDBWrapper, d time.Duration) {
+	if obs, ok := p.Selector.(LatencyObserver); ok {
+		obs.Observe(wrapper, d)
+	}
+}
@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+	"fmt"
+	"runtime/debug"
 
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -19,11 +21,34 @@ type GormDBInterface interface {
 	Begin() GormDBInterface
 	Commit() GormDBInterface
 	Rollback() GormDBInterface
+	SavePoint(name string) GormDBInterface
+	RollbackTo(name string) GormDBInterface
+}
+
+// PanicError wraps a value recovered from a panic raised inside a
+// Transaction callback, preserving the original stack trace so the
+// panic can be diagnosed after it has been turned into a rolled-back
+// transaction error instead of crashing the process.
+type PanicError struct {
+	Recovered interface{}
+	Stack     []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic recovered inside transaction: %v\n%s", e.Recovered, e.Stack)
 }
 
 type GormDBWrapper struct {
 	DB *gorm.DB
 	*mock.Mock
+
+	// inTx is true while this wrapper is executing inside a Transaction
+	// call. A Transaction call made while inTx is already true is nested
+	// and is executed as a SAVEPOINT instead of a new BEGIN/COMMIT.
+	inTx bool
+	// txDepth counts the currently open savepoints, used to derive unique
+	// savepoint names (sp_1, sp_2, ...).
+	txDepth int
 }
 
 func (w *GormDBWrapper) Create(value interface{}) GormDBInterface {
@@ -90,12 +115,39 @@ func (w *GormDBWrapper) GetDB() *gorm.DB {
 	return w.DB
 }
 
+// Begin starts a transaction. In mock mode it marks w itself as inTx,
+// matching the single self-returning wrapper tests expect. Otherwise it
+// returns a distinct child wrapper holding the new transaction's *gorm.DB
+// and its own inTx/txDepth fields, so that concurrent top-level
+// Transaction() calls sharing w (e.g. a GormDBPool's Primary used across
+// request handlers) never contend on the same reentrancy state -- each
+// gets its own child, and only code that is handed that specific child
+// (i.e. genuinely nested inside its callback) can trigger the savepoint
+// path.
 func (w *GormDBWrapper) Begin() GormDBInterface {
 	if w.Mock != nil {
 		w.Mock.Called()
+		w.inTx = true
 		return w
 	}
-	w.DB = w.DB.Begin()
+	return &GormDBWrapper{DB: w.DB.Begin(), inTx: true}
+}
+
+func (w *GormDBWrapper) SavePoint(name string) GormDBInterface {
+	if w.Mock != nil {
+		w.Mock.Called(name)
+		return w
+	}
+	w.DB = w.DB.SavePoint(name)
+	return w
+}
+
+func (w *GormDBWrapper) RollbackTo(name string) GormDBInterface {
+	if w.Mock != nil {
+		w.Mock.Called(name)
+		return w
+	}
+	w.DB = w.DB.RollbackTo(name)
 	return w
 }
 
@@ -117,23 +169,87 @@ func (w *GormDBWrapper) Commit() GormDBInterface {
 	return w
 }
 
+// Transaction runs fc within a database transaction. If w is already
+// inside a transaction (i.e. w is the tx wrapper handed to an enclosing
+// Transaction callback), it is executed as a SAVEPOINT
+// so repository methods can compose without losing atomicity; only the
+// outermost call issues a real BEGIN/COMMIT/ROLLBACK. A panic raised by
+// fc is recovered, the transaction (or savepoint) is rolled back, and
+// the panic is returned as a *PanicError with the original stack
+// attached.
 func (w *GormDBWrapper) Transaction(fc func(tx GormDBInterface) error) error {
+	if w.inTx {
+		return w.transactionNested(fc)
+	}
+	return w.transactionTopLevel(fc)
+}
+
+// resetTxState clears the inTx/txDepth reentrancy markers on whichever
+// wrapper actually carries them for this call: w itself in mock mode
+// (Begin returns w), or the child Begin() allocated in real mode. Using
+// the txWrapper here rather than always touching w is what keeps
+// concurrent top-level Transaction() calls on a shared real wrapper from
+// stepping on each other's state.
+func resetTxState(txWrapper GormDBInterface) {
+	if tw, ok := txWrapper.(*GormDBWrapper); ok {
+		tw.inTx = false
+		tw.txDepth = 0
+	}
+}
+
+func (w *GormDBWrapper) transactionTopLevel(fc func(tx GormDBInterface) error) (err error) {
 	var txWrapper GormDBInterface
 
 	txWrapper = w.Begin()
 	if txWrapper.GetDB().Error != nil {
+		resetTxState(txWrapper)
 		return txWrapper.GetDB().Error
 	}
 
+	defer func() {
+		if r := recover(); r != nil {
+			txWrapper.Rollback()
+			resetTxState(txWrapper)
+			err = &PanicError{Recovered: r, Stack: debug.Stack()}
+		}
+	}()
+
 	// Execute the function with the transaction wrapper
-	err := fc(txWrapper)
-	if err != nil {
+	if ferr := fc(txWrapper); ferr != nil {
 		// If there is an error, rollback the transaction
 		txWrapper.Rollback()
-		return err
+		resetTxState(txWrapper)
+		return ferr
 	}
 
 	// Commit the transaction if all is well
 	txWrapper.Commit()
+	resetTxState(txWrapper)
+	return nil
+}
+
+func (w *GormDBWrapper) transactionNested(fc func(tx GormDBInterface) error) (err error) {
+	w.txDepth++
+	spName := fmt.Sprintf("sp_%d", w.txDepth)
+
+	txWrapper := w.SavePoint(spName)
+	if txWrapper.GetDB().Error != nil {
+		w.txDepth--
+		return txWrapper.GetDB().Error
+	}
+
+	defer func() {
+		w.txDepth--
+		if r := recover(); r != nil {
+			txWrapper.RollbackTo(spName)
+			err = &PanicError{Recovered: r, Stack: debug.Stack()}
+		}
+	}()
+
+	if ferr := fc(txWrapper); ferr != nil {
+		txWrapper.RollbackTo(spName)
+		return ferr
+	}
+
 	return nil
 }
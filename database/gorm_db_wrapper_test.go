@@ -0,0 +1,112 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// freshWrapper returns a GormDBWrapper over its own session, so tests
+// that execute real (non-mocked) queries never mutate the package-level
+// repo.DB shared by other tests. Session(&gorm.Session{NewDB: true})
+// isolates the Statement but still copies the parent *gorm.DB's Error
+// field forward, so it's cleared explicitly -- otherwise a stale error
+// left on the shared db by an earlier, unrelated test (e.g. a mocked
+// transaction test that sets db.Error directly) would silently poison
+// every later freshWrapper() session too.
+func freshWrapper() *GormDBWrapper {
+	session := db.Session(&gorm.Session{NewDB: true})
+	session.Error = nil
+	return &GormDBWrapper{DB: session}
+}
+
+func TestGormDBWrapper_Transaction_NestedSavepointRollsBackIndependently(t *testing.T) {
+	wrapper := freshWrapper()
+	assert.NoError(t, wrapper.DB.Exec("DELETE FROM test_models").Error)
+
+	err := wrapper.Transaction(func(outer GormDBInterface) error {
+		if err := outer.Create(&TestModel{Name: "outer"}).GetDB().Error; err != nil {
+			return err
+		}
+
+		// The nested transaction fails and should only roll back its own
+		// savepoint, leaving "outer" committed once the top-level
+		// transaction commits.
+		innerErr := outer.Transaction(func(inner GormDBInterface) error {
+			if err := inner.Create(&TestModel{Name: "inner"}).GetDB().Error; err != nil {
+				return err
+			}
+			return errors.New("inner failure")
+		})
+		assert.Error(t, innerErr)
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+
+	var names []string
+	assert.NoError(t, wrapper.DB.Model(&TestModel{}).Order("name").Pluck("name", &names).Error)
+	assert.Equal(t, []string{"outer"}, names)
+}
+
+func TestGormDBWrapper_Transaction_PanicRecoversAndRollsBack(t *testing.T) {
+	wrapper := freshWrapper()
+	assert.NoError(t, wrapper.DB.Exec("DELETE FROM test_models").Error)
+
+	err := wrapper.Transaction(func(tx GormDBInterface) error {
+		tx.Create(&TestModel{Name: "before panic"})
+		panic("kaboom")
+	})
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "kaboom", panicErr.Recovered)
+
+	var count int64
+	assert.NoError(t, wrapper.DB.Model(&TestModel{}).Count(&count).Error)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestGormDBWrapper_Transaction_ConcurrentTopLevelCallsAreIndependent(t *testing.T) {
+	wrapper := freshWrapper()
+	assert.NoError(t, wrapper.DB.Exec("DELETE FROM test_models").Error)
+
+	const n = 8
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Concurrent top-level Transaction() calls on the same shared
+			// wrapper (as happens when a GormDBPool's Primary is used
+			// across request handlers) must never be misdetected as
+			// nested into one another. Retry absorbs SQLITE_BUSY from the
+			// resulting lock contention on the shared in-memory db.
+			errs[i] = wrapper.TransactionWith(func(tx GormDBInterface) error {
+				return tx.Create(&TestModel{Name: fmt.Sprintf("concurrent-%d", i)}).GetDB().Error
+			}, WithRetry(RetryPolicy{
+				MaxAttempts: 20,
+				BaseBackoff: time.Millisecond,
+				MaxBackoff:  20 * time.Millisecond,
+				Jitter:      true,
+			}))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		assert.NoErrorf(t, err, "goroutine %d", i)
+	}
+
+	var count int64
+	assert.NoError(t, wrapper.DB.Model(&TestModel{}).Where("name LIKE ?", "concurrent-%").Count(&count).Error)
+	assert.Equal(t, int64(n), count)
+}
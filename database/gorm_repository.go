@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+	"reflect"
+	"time"
 )
 
 // Generic database operations interface
@@ -11,11 +13,21 @@ type Repository[T any] interface {
 	First(ctx context.Context, conds ...interface{}) (T, error)
 	Save(ctx context.Context, obj T) error
 	Delete(ctx context.Context, obj T) error
+	// FindRO and FirstRO are read-only variants of Find/First that, when
+	// the repository was built with a replica pool, are routed to a
+	// replica instead of the primary. Use WithForcedPrimary on ctx to
+	// pin a single read to the primary.
+	FindRO(ctx context.Context, conds ...interface{}) ([]T, error)
+	FirstRO(ctx context.Context, conds ...interface{}) (T, error)
 }
 
 // GormDatabase is a GORM implementation of the Repository interface
 type GormRepository[T any] struct {
 	DB *GormDBWrapper
+	// Pool, when set, routes FindRO/FirstRO reads to a replica instead
+	// of DB. Create/Save/Delete always use DB directly, which is the
+	// primary when the repository was built via NewGormDatabaseWithPool.
+	Pool *GormDBPool
 }
 
 // NewGormDatabase creates a new instance of GormDatabase
@@ -23,29 +35,81 @@ func NewGormDatabase[T any](db *GormDBWrapper) *GormRepository[T] {
 	return &GormRepository[T]{DB: db}
 }
 
+// NewGormDatabaseWithPool creates a GormDatabase whose writes go through
+// pool.Primary and whose FindRO/FirstRO reads are routed to a replica by
+// pool.Selector.
+func NewGormDatabaseWithPool[T any](pool *GormDBPool) *GormRepository[T] {
+	return &GormRepository[T]{DB: pool.Primary, Pool: pool}
+}
+
+// modelName returns the unqualified type name of T, used to annotate
+// QueryError with the model a failing operation was acting on.
+func modelName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().Name()
+}
+
 func (gdb *GormRepository[T]) Create(ctx context.Context, obj T) error {
 	var with_context = gdb.DB.WithContext(ctx)
 	var create = with_context.Create(&obj)
 	var db = create.GetDB()
-	return db.Error
+	return translateError(db.Error, "Create", modelName[T]())
 }
 
 func (gdb *GormRepository[T]) Find(ctx context.Context, conds ...interface{}) ([]T, error) {
 	var results []T
 	err := gdb.DB.WithContext(ctx).Find(&results, conds...).GetDB().Error
-	return results, err
+	return results, translateError(err, "Find", modelName[T]())
 }
 
 func (gdb *GormRepository[T]) First(ctx context.Context, conds ...interface{}) (T, error) {
 	var result T
 	err := gdb.DB.WithContext(ctx).First(&result, conds...).GetDB().Error
-	return result, err
+	return result, translateError(err, "First", modelName[T]())
 }
 
 func (gdb *GormRepository[T]) Save(ctx context.Context, obj T) error {
-	return gdb.DB.WithContext(ctx).Save(&obj).GetDB().Error
+	err := gdb.DB.WithContext(ctx).Save(&obj).GetDB().Error
+	return translateError(err, "Save", modelName[T]())
 }
 
 func (gdb *GormRepository[T]) Delete(ctx context.Context, obj T) error {
-	return gdb.DB.WithContext(ctx).Delete(&obj).GetDB().Error
+	err := gdb.DB.WithContext(ctx).Delete(&obj).GetDB().Error
+	return translateError(err, "Delete", modelName[T]())
+}
+
+// reader returns the wrapper a read for ctx should be issued against,
+// timing the query and reporting the duration back to gdb.Pool's
+// selector when one is configured.
+func (gdb *GormRepository[T]) reader(ctx context.Context) (*GormDBWrapper, func()) {
+	if gdb.Pool == nil {
+		return gdb.DB, func() {}
+	}
+
+	wrapper := gdb.Pool.pickReader(ctx)
+	start := time.Now()
+	return wrapper, func() { gdb.Pool.observeLatency(wrapper, time.Since(start)) }
+}
+
+func (gdb *GormRepository[T]) FindRO(ctx context.Context, conds ...interface{}) ([]T, error) {
+	reader, done := gdb.reader(ctx)
+	defer done()
+
+	// Derive a local session from reader.DB directly instead of going
+	// through reader.WithContext(ctx), which would reassign reader.DB in
+	// place -- a data race when reader is a pool replica shared across
+	// concurrent reads. reader.DB itself is only ever read here, never
+	// written, so concurrent FindRO/FirstRO calls never touch the same
+	// memory.
+	var results []T
+	err := reader.DB.WithContext(ctx).Find(&results, conds...).Error
+	return results, translateError(err, "FindRO", modelName[T]())
+}
+
+func (gdb *GormRepository[T]) FirstRO(ctx context.Context, conds ...interface{}) (T, error) {
+	reader, done := gdb.reader(ctx)
+	defer done()
+
+	var result T
+	err := reader.DB.WithContext(ctx).First(&result, conds...).Error
+	return result, translateError(err, "FirstRO", modelName[T]())
 }
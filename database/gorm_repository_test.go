@@ -24,11 +24,25 @@ var (
 
 func TestMain(m *testing.M) {
 	var err error
-	db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	// A plain ":memory:" DSN hands out a fresh, unmigrated database to
+	// every new physical connection; under concurrent access that starves
+	// the default unbounded pool into opening more than one, and queries
+	// on those extra connections fail with "no such table". The named,
+	// shared-cache DSN plus a single-connection pool keeps every
+	// connection pointed at the one migrated in-memory database. The name
+	// is unique to this package-level db so it can't alias the separate
+	// replica databases opened by gorm_db_pool_test.go.
+	db, err = gorm.Open(sqlite.Open("file:primary?mode=memory&cache=shared"), &gorm.Config{})
 	if err != nil {
 		panic("failed to connect to in-memory database")
 	}
 
+	sqlDB, err := db.DB()
+	if err != nil {
+		panic("failed to access underlying sql.DB")
+	}
+	sqlDB.SetMaxOpenConns(1)
+
 	err = db.AutoMigrate(&TestModel{})
 	if err != nil {
 		panic("failed to auto-migrate")
@@ -78,7 +92,7 @@ func TestGormRepository_Create_Negative(t *testing.T) {
 
 	err := repo.Create(ctx, model)
 	assert.Error(t, err)
-	assert.Equal(t, fakeError, err)
+	assert.ErrorIs(t, err, fakeError)
 	repo.DB.Mock.AssertExpectations(t)
 }
 
@@ -109,7 +123,7 @@ func TestGormRepository_Find_Negative(t *testing.T) {
 
 	_, err := repo.Find(ctx, "ID = ?", -1)
 	assert.Error(t, err)
-	assert.Equal(t, fakeError, err)
+	assert.ErrorIs(t, err, fakeError)
 	repo.DB.Mock.AssertExpectations(t)
 }
 
@@ -140,7 +154,7 @@ func TestGormRepository_First_Negative(t *testing.T) {
 
 	_, err := repo.First(ctx, "ID = ?", -1)
 	assert.Error(t, err)
-	assert.Equal(t, fakeError, err)
+	assert.ErrorIs(t, err, fakeError)
 	repo.DB.Mock.AssertExpectations(t)
 }
 
@@ -172,7 +186,7 @@ func TestGormRepository_Save_Negative(t *testing.T) {
 
 	err := repo.Save(ctx, model)
 	assert.Error(t, err)
-	assert.Equal(t, fakeError, err)
+	assert.ErrorIs(t, err, fakeError)
 	repo.DB.Mock.AssertExpectations(t)
 }
 
@@ -204,7 +218,7 @@ func TestGormRepository_Delete_Negative(t *testing.T) {
 
 	err := repo.Delete(ctx, model)
 	assert.Error(t, err)
-	assert.Equal(t, fakeError, err)
+	assert.ErrorIs(t, err, fakeError)
 	repo.DB.Mock.AssertExpectations(t)
 }
 
@@ -0,0 +1,175 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"gorm.io/gorm"
+)
+
+type whereClause struct {
+	expr string
+	args []interface{}
+	or   bool
+}
+
+// Query is a fluent builder over a GormRepository[T] that accumulates
+// conditions, ordering, pagination and field selection before issuing a
+// single query (or, via Page, a COUNT(*) plus a query) within the same
+// WithContext.
+type Query[T any] struct {
+	ctx context.Context
+	db  *GormDBWrapper
+
+	wheres   []whereClause
+	order    []string
+	limit    int
+	offset   int
+	selects  []string
+	preloads []string
+}
+
+// Query returns a fluent query builder scoped to ctx.
+func (gdb *GormRepository[T]) Query(ctx context.Context) *Query[T] {
+	return &Query[T]{ctx: ctx, db: gdb.DB, limit: -1, offset: -1}
+}
+
+func (q *Query[T]) Where(expr string, args ...interface{}) *Query[T] {
+	q.wheres = append(q.wheres, whereClause{expr: expr, args: args})
+	return q
+}
+
+func (q *Query[T]) OrWhere(expr string, args ...interface{}) *Query[T] {
+	q.wheres = append(q.wheres, whereClause{expr: expr, args: args, or: true})
+	return q
+}
+
+func (q *Query[T]) Order(col, dir string) *Query[T] {
+	q.order = append(q.order, fmt.Sprintf("%s %s", col, dir))
+	return q
+}
+
+func (q *Query[T]) Limit(n int) *Query[T] {
+	q.limit = n
+	return q
+}
+
+func (q *Query[T]) Offset(n int) *Query[T] {
+	q.offset = n
+	return q
+}
+
+func (q *Query[T]) Select(cols ...string) *Query[T] {
+	q.selects = append(q.selects, cols...)
+	return q
+}
+
+func (q *Query[T]) Preload(rel string) *Query[T] {
+	q.preloads = append(q.preloads, rel)
+	return q
+}
+
+// build applies the accumulated clauses to a fresh *gorm.DB scoped to T.
+func (q *Query[T]) build() *gorm.DB {
+	tx := q.db.WithContext(q.ctx).GetDB().Model(new(T))
+
+	for _, w := range q.wheres {
+		if w.or {
+			tx = tx.Or(w.expr, w.args...)
+		} else {
+			tx = tx.Where(w.expr, w.args...)
+		}
+	}
+	for _, o := range q.order {
+		tx = tx.Order(o)
+	}
+	if len(q.selects) > 0 {
+		tx = tx.Select(q.selects)
+	}
+	for _, p := range q.preloads {
+		tx = tx.Preload(p)
+	}
+	if q.limit >= 0 {
+		tx = tx.Limit(q.limit)
+	}
+	if q.offset >= 0 {
+		tx = tx.Offset(q.offset)
+	}
+	return tx
+}
+
+// Page holds one page of results from Query.Page.
+type Page[T any] struct {
+	Items    []T
+	Total    int64
+	PageNum  int
+	PageSize int
+	HasNext  bool
+}
+
+// Page executes the query for the num'th page (1-indexed) of size
+// entries, issuing a single COUNT(*) and the data query within the same
+// WithContext. Limit/Offset set on q are overridden by num/size.
+func (q *Query[T]) Page(num, size int) (Page[T], error) {
+	if num < 1 {
+		num = 1
+	}
+	if size < 1 {
+		size = 1
+	}
+
+	// A caller-supplied Limit/Offset is replaced by num/size for the data
+	// fetch below and cleared for the row count: per gorm's Count-with-Limit
+	// gotcha, a Count() issued on a tx that still carries a Limit/Offset
+	// clause counts only within that window, producing a wrong Total/HasNext.
+	var total int64
+	if err := q.build().Limit(-1).Offset(-1).Count(&total).Error; err != nil {
+		return Page[T]{}, translateError(err, "Page", modelName[T]())
+	}
+
+	var items []T
+	// gorm keeps an earlier Offset when the new one is 0, so a caller
+	// Offset is cleared with Offset(-1) before page 1's Offset(0).
+	tx := q.build().Offset(-1).Limit(size).Offset((num - 1) * size)
+	if err := tx.Find(&items).Error; err != nil {
+		return Page[T]{}, translateError(err, "Page", modelName[T]())
+	}
+
+	return Page[T]{
+		Items:    items,
+		Total:    total,
+		PageNum:  num,
+		PageSize: size,
+		HasNext:  int64(num*size) < total,
+	}, nil
+}
+
+// errStreamStopped is a sentinel used to unwind out of FindInBatches
+// when the Stream consumer stops ranging early; it is never surfaced to
+// callers.
+var errStreamStopped = errors.New("database: stream stopped by consumer")
+
+// Stream iterates the query result set in batches of batchSize using
+// FindInBatches, so large exports don't have to hold the full result
+// set in memory. Range over the returned sequence with a two-value
+// range-over-func loop; a non-nil error ends the sequence.
+func (q *Query[T]) Stream(batchSize int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var batch []T
+		err := q.build().FindInBatches(&batch, batchSize, func(tx *gorm.DB, batchNum int) error {
+			for _, item := range batch {
+				if !yield(item, nil) {
+					return errStreamStopped
+				}
+			}
+			return nil
+		}).Error
+
+		if err != nil && !errors.Is(err, errStreamStopped) {
+			var zero T
+			yield(zero, translateError(err, "Stream", modelName[T]()))
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func seedTestModels(t *testing.T, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		assert.NoError(t, freshWrapper().DB.Create(&TestModel{Name: name}).Error)
+	}
+}
+
+// freshQueryTestRepo wraps freshWrapper (see its doc comment) in a repo
+// for tests that run real, non-mocked queries through the Query builder.
+func freshQueryTestRepo() *GormRepository[TestModel] {
+	return NewGormDatabase[TestModel](freshWrapper())
+}
+
+func TestQuery_Page(t *testing.T) {
+	assert.NoError(t, freshWrapper().DB.Exec("DELETE FROM test_models").Error)
+	seedTestModels(t, "alpha", "bravo", "charlie", "delta")
+	testRepo := freshQueryTestRepo()
+
+	page, err := testRepo.Query(context.Background()).Order("name", "asc").Page(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), page.Total)
+	assert.Equal(t, 1, page.PageNum)
+	assert.Equal(t, 2, page.PageSize)
+	assert.True(t, page.HasNext)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, "alpha", page.Items[0].Name)
+
+	lastPage, err := testRepo.Query(context.Background()).Order("name", "asc").Page(2, 2)
+	assert.NoError(t, err)
+	assert.False(t, lastPage.HasNext)
+	assert.Len(t, lastPage.Items, 2)
+}
+
+func TestQuery_WhereFiltersResults(t *testing.T) {
+	assert.NoError(t, freshWrapper().DB.Exec("DELETE FROM test_models").Error)
+	seedTestModels(t, "alpha", "bravo")
+	testRepo := freshQueryTestRepo()
+
+	page, err := testRepo.Query(context.Background()).Where("name = ?", "bravo").Page(1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), page.Total)
+	assert.Equal(t, "bravo", page.Items[0].Name)
+}
+
+func TestQuery_Page_IgnoresCallerLimitAndOffsetForCount(t *testing.T) {
+	assert.NoError(t, freshWrapper().DB.Exec("DELETE FROM test_models").Error)
+	seedTestModels(t, "alpha", "bravo", "charlie", "delta")
+	testRepo := freshQueryTestRepo()
+
+	// Page replaces a caller-supplied Limit/Offset with num/size; its own
+	// COUNT(*) must see the full, unfiltered row count.
+	page, err := testRepo.Query(context.Background()).Order("name", "asc").Limit(1).Offset(1).Page(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), page.Total)
+	assert.True(t, page.HasNext)
+}
+
+func TestQuery_Stream(t *testing.T) {
+	assert.NoError(t, freshWrapper().DB.Exec("DELETE FROM test_models").Error)
+	seedTestModels(t, "alpha", "bravo", "charlie")
+	testRepo := freshQueryTestRepo()
+
+	var seen []string
+	for item, err := range testRepo.Query(context.Background()).Order("name", "asc").Stream(1) {
+		assert.NoError(t, err)
+		seen = append(seen, item.Name)
+	}
+	assert.Equal(t, []string{"alpha", "bravo", "charlie"}, seen)
+}
+
+func TestQuery_Stream_StopsEarly(t *testing.T) {
+	assert.NoError(t, freshWrapper().DB.Exec("DELETE FROM test_models").Error)
+	seedTestModels(t, "alpha", "bravo", "charlie")
+	testRepo := freshQueryTestRepo()
+
+	var seen []string
+	for item, err := range testRepo.Query(context.Background()).Order("name", "asc").Stream(1) {
+		assert.NoError(t, err)
+		seen = append(seen, item.Name)
+		if len(seen) == 1 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"alpha"}, seen)
+}
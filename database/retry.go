@@ -0,0 +1,105 @@
+package database
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy configures how GormDBWrapper.TransactionWith retries a
+// transaction callback that fails with a transient/serialization error.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Jitter      bool
+
+	// Classify reports whether err is worth retrying. Defaults to the
+	// same classification Create/Find/... use to produce
+	// ErrSerializationFailure (sqlite SQLITE_BUSY, postgres 40001/40P01,
+	// mysql 1213/1205) when nil.
+	Classify func(err error) bool
+
+	// OnRetry, when set, is called with the attempt number (1-indexed)
+	// and the error that triggered the retry, after rollback and before
+	// the backoff sleep, so callers can count or log retries.
+	OnRetry func(attempt int, err error)
+}
+
+func (p RetryPolicy) classify(err error) bool {
+	if p.Classify != nil {
+		return p.Classify(err)
+	}
+	return isSerializationFailure(err)
+}
+
+// backoff returns the delay before the (attempt+1)'th try, exponential
+// in attempt and capped at MaxBackoff, optionally randomized by Jitter.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	base := p.BaseBackoff
+	if base <= 0 {
+		base = 10 * time.Millisecond
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = time.Second
+	}
+
+	d := base * time.Duration(uint64(1)<<uint(attempt-1))
+	if d <= 0 || d > maxBackoff {
+		d = maxBackoff
+	}
+	if p.Jitter {
+		d = time.Duration(rand.Int63n(int64(d) + 1))
+	}
+	return d
+}
+
+// TransactionOption configures a single TransactionWith call.
+type TransactionOption func(*transactionConfig)
+
+type transactionConfig struct {
+	retry *RetryPolicy
+}
+
+// WithRetry makes TransactionWith retry fc when it fails with an error
+// policy.Classify considers transient, rolling back and starting a
+// fresh transaction before each retry rather than reusing the stale one.
+func WithRetry(policy RetryPolicy) TransactionOption {
+	return func(cfg *transactionConfig) {
+		cfg.retry = &policy
+	}
+}
+
+// TransactionWith runs fc within a transaction like Transaction, plus
+// whatever behavior opts configure. With no options it behaves exactly
+// like Transaction.
+func (w *GormDBWrapper) TransactionWith(fc func(tx GormDBInterface) error, opts ...TransactionOption) error {
+	cfg := &transactionConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.retry == nil {
+		return w.Transaction(fc)
+	}
+
+	policy := *cfg.retry
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = w.Transaction(fc)
+		if err == nil || !policy.classify(err) || attempt == maxAttempts {
+			return err
+		}
+
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err)
+		}
+		time.Sleep(policy.backoff(attempt))
+	}
+	return err
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRetryTestWrapper() *GormDBWrapper {
+	w := &GormDBWrapper{DB: freshWrapper().DB, Mock: new(mock.Mock)}
+	w.Mock.On("Begin").Return(w)
+	w.Mock.On("Commit").Return(w)
+	w.Mock.On("Rollback").Return(w)
+	return w
+}
+
+func TestTransactionWith_NoOptions_BehavesLikeTransaction(t *testing.T) {
+	w := newRetryTestWrapper()
+
+	err := w.TransactionWith(func(tx GormDBInterface) error {
+		return nil
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestTransactionWith_RetriesClassifiedError(t *testing.T) {
+	w := newRetryTestWrapper()
+
+	attempts := 0
+	fc := func(tx GormDBInterface) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("database is locked (SQLITE_BUSY)")
+		}
+		return nil
+	}
+
+	var retried []int
+	err := w.TransactionWith(fc, WithRetry(RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+		OnRetry: func(attempt int, err error) {
+			retried = append(retried, attempt)
+		},
+	}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []int{1, 2}, retried)
+}
+
+func TestTransactionWith_StopsOnNonRetryableError(t *testing.T) {
+	w := newRetryTestWrapper()
+
+	attempts := 0
+	fc := func(tx GormDBInterface) error {
+		attempts++
+		return errors.New("not a transient error")
+	}
+
+	err := w.TransactionWith(fc, WithRetry(RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+	}))
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestTransactionWith_ExhaustsMaxAttempts(t *testing.T) {
+	w := newRetryTestWrapper()
+
+	attempts := 0
+	fc := func(tx GormDBInterface) error {
+		attempts++
+		return errors.New("Error 1213: Deadlock found when trying to get lock")
+	}
+
+	err := w.TransactionWith(fc, WithRetry(RetryPolicy{
+		MaxAttempts: 2,
+		BaseBackoff: time.Millisecond,
+	}))
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestTransactionWith_CustomClassify(t *testing.T) {
+	w := newRetryTestWrapper()
+
+	attempts := 0
+	sentinel := errors.New("custom retryable")
+	fc := func(tx GormDBInterface) error {
+		attempts++
+		if attempts < 2 {
+			return sentinel
+		}
+		return nil
+	}
+
+	err := w.TransactionWith(fc, WithRetry(RetryPolicy{
+		MaxAttempts: 2,
+		BaseBackoff: time.Millisecond,
+		Classify:    func(err error) bool { return errors.Is(err, sentinel) },
+	}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+}